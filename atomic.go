@@ -0,0 +1,166 @@
+package cache
+
+// Add inserts object under key only if the key is absent or expired.
+// If the key already exists and hasn't expired, it returns ErrKeyExists and
+// leaves the cache untouched.
+func (c *Cache) Add(key string, object interface{}) error {
+	if key == "" {
+		return ErrEmptyKey
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i := c.hash(key)
+	c.rwMu[i].Lock()
+	defer c.rwMu[i].Unlock()
+
+	if v, found := c.items[i][key]; found {
+		if !isExpired(v.Expiration) {
+			return ErrKeyExists
+		}
+		if c.onEvicted != nil {
+			c.onEvicted(key, v.Object)
+		}
+		v.Object = object
+		v.Expiration = effectiveExpiration(c.expiration)
+		c.touchLRU(i, v, key)
+		return nil
+	}
+
+	item := &Item[interface{}]{Object: object, Expiration: effectiveExpiration(c.expiration)}
+	c.items[i][key] = item
+	c.touchLRU(i, item, key)
+	c.evictLRUIfFull(i)
+	return nil
+}
+
+// mutateNumeric applies apply to the object stored under key under the
+// shard's write lock and stores the value apply produced in its place. It
+// returns only an error; callers capture the new value inside apply.
+func (c *Cache) mutateNumeric(key string, apply func(interface{}) (interface{}, error)) error {
+	if key == "" {
+		return ErrEmptyKey
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i := c.hash(key)
+	c.rwMu[i].Lock()
+	defer c.rwMu[i].Unlock()
+
+	v, found := c.items[i][key]
+	if !found {
+		return ErrNotFound
+	}
+	if isExpired(v.Expiration) {
+		return ErrExpired
+	}
+
+	newObject, err := apply(v.Object)
+	if err != nil {
+		return err
+	}
+	v.Object = newObject
+	return nil
+}
+
+func applyIntDelta(object interface{}, delta int64) (interface{}, int64, error) {
+	switch v := object.(type) {
+	case int:
+		nv := v + int(delta)
+		return nv, int64(nv), nil
+	case int8:
+		nv := v + int8(delta)
+		return nv, int64(nv), nil
+	case int16:
+		nv := v + int16(delta)
+		return nv, int64(nv), nil
+	case int32:
+		nv := v + int32(delta)
+		return nv, int64(nv), nil
+	case int64:
+		nv := v + delta
+		return nv, nv, nil
+	case uint:
+		nv := v + uint(delta)
+		return nv, int64(nv), nil
+	case uint8:
+		nv := v + uint8(delta)
+		return nv, int64(nv), nil
+	case uint16:
+		nv := v + uint16(delta)
+		return nv, int64(nv), nil
+	case uint32:
+		nv := v + uint32(delta)
+		return nv, int64(nv), nil
+	case uint64:
+		nv := v + uint64(delta)
+		return nv, int64(nv), nil
+	default:
+		return nil, 0, ErrTypeMismatch
+	}
+}
+
+func applyFloatDelta(object interface{}, delta float64) (interface{}, float64, error) {
+	switch v := object.(type) {
+	case float32:
+		nv := v + float32(delta)
+		return nv, float64(nv), nil
+	case float64:
+		nv := v + delta
+		return nv, nv, nil
+	default:
+		return nil, 0, ErrTypeMismatch
+	}
+}
+
+// Increment adds delta to the int-family value stored under key and returns
+// the new value. Returns ErrNotFound, ErrExpired, or ErrTypeMismatch if the
+// stored value isn't one of the standard int/uint widths.
+func (c *Cache) Increment(key string, delta int64) (int64, error) {
+	var result int64
+	err := c.mutateNumeric(key, func(object interface{}) (interface{}, error) {
+		newObject, newValue, err := applyIntDelta(object, delta)
+		if err != nil {
+			return nil, err
+		}
+		result = newValue
+		return newObject, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+// Decrement subtracts delta from the int-family value stored under key and
+// returns the new value. See Increment for error conditions.
+func (c *Cache) Decrement(key string, delta int64) (int64, error) {
+	return c.Increment(key, -delta)
+}
+
+// IncrementFloat adds delta to the float32/float64 value stored under key
+// and returns the new value. See Increment for error conditions.
+func (c *Cache) IncrementFloat(key string, delta float64) (float64, error) {
+	var result float64
+	err := c.mutateNumeric(key, func(object interface{}) (interface{}, error) {
+		newObject, newValue, err := applyFloatDelta(object, delta)
+		if err != nil {
+			return nil, err
+		}
+		result = newValue
+		return newObject, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+// DecrementFloat subtracts delta from the float32/float64 value stored under
+// key and returns the new value. See Increment for error conditions.
+func (c *Cache) DecrementFloat(key string, delta float64) (float64, error) {
+	return c.IncrementFloat(key, -delta)
+}
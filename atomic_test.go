@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCacheAdd(t *testing.T) {
+	ctx := context.Background()
+	c := New(ctx, Options{Expiration: time.Minute})
+
+	if err := c.Add("key", 1); err != nil {
+		t.Fatalf("Add() = %v, want nil", err)
+	}
+	if err := c.Add("key", 2); err != ErrKeyExists {
+		t.Fatalf("Add() on existing key = %v, want ErrKeyExists", err)
+	}
+	if v, _ := c.Get("key"); v != 1 {
+		t.Fatalf("Get(key) = %v, want 1 (unchanged)", v)
+	}
+}
+
+func TestCacheAddReplacesExpiredItem(t *testing.T) {
+	ctx := context.Background()
+	c := New(ctx, Options{Expiration: time.Millisecond})
+	c.Set("key", "old")
+	time.Sleep(10 * time.Millisecond)
+
+	if err := c.Add("key", "new"); err != nil {
+		t.Fatalf("Add() over an expired key = %v, want nil", err)
+	}
+	if v, err := c.Get("key"); err != nil || v != "new" {
+		t.Fatalf("Get(key) = %v, %v, want \"new\", nil", v, err)
+	}
+}
+
+func TestCacheIncrementDecrement(t *testing.T) {
+	ctx := context.Background()
+	c := New(ctx, Options{Expiration: time.Minute})
+	c.Set("n", 10)
+
+	if v, err := c.Increment("n", 5); err != nil || v != 15 {
+		t.Fatalf("Increment() = %v, %v, want 15, nil", v, err)
+	}
+	if v, err := c.Decrement("n", 3); err != nil || v != 12 {
+		t.Fatalf("Decrement() = %v, %v, want 12, nil", v, err)
+	}
+
+	c.Set("f", 1.5)
+	if v, err := c.IncrementFloat("f", 0.5); err != nil || v != 2.0 {
+		t.Fatalf("IncrementFloat() = %v, %v, want 2.0, nil", v, err)
+	}
+	if v, err := c.DecrementFloat("f", 1.0); err != nil || v != 1.0 {
+		t.Fatalf("DecrementFloat() = %v, %v, want 1.0, nil", v, err)
+	}
+}
+
+func TestCacheIncrementErrors(t *testing.T) {
+	ctx := context.Background()
+	c := New(ctx, Options{Expiration: time.Millisecond})
+
+	if _, err := c.Increment("missing", 1); err != ErrNotFound {
+		t.Fatalf("Increment(missing) = %v, want ErrNotFound", err)
+	}
+
+	c.Set("str", "not a number")
+	if _, err := c.Increment("str", 1); err != ErrTypeMismatch {
+		t.Fatalf("Increment(str) = %v, want ErrTypeMismatch", err)
+	}
+	if _, err := c.IncrementFloat("str", 1); err != ErrTypeMismatch {
+		t.Fatalf("IncrementFloat(str) = %v, want ErrTypeMismatch", err)
+	}
+
+	c.Set("n", 1)
+	time.Sleep(10 * time.Millisecond)
+	if _, err := c.Increment("n", 1); err != ErrExpired {
+		t.Fatalf("Increment(n) on expired item = %v, want ErrExpired", err)
+	}
+}
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNoExpirationCacheDefault(t *testing.T) {
+	ctx := context.Background()
+	c := New(ctx, Options{Expiration: NoExpiration})
+
+	c.Set("key", "value")
+
+	if _, err := c.Get("key"); err != nil {
+		t.Fatalf("Get() = _, %v, want no error", err)
+	}
+
+	exp, expired := c.Expired("key")
+	if !exp.IsZero() || expired {
+		t.Fatalf("Expired() = %v, %v, want zero time, false", exp, expired)
+	}
+
+	c.DeleteExpired()
+	if _, err := c.Get("key"); err != nil {
+		t.Fatalf("Get() after DeleteExpired() = _, %v, want no error", err)
+	}
+}
+
+func TestNoExpirationPerItem(t *testing.T) {
+	ctx := context.Background()
+	c := New(ctx, Options{Expiration: time.Millisecond})
+
+	if err := c.SetWithExpiration("key", "value", NoExpiration); err != nil {
+		t.Fatalf("SetWithExpiration() = %v, want nil", err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	if _, err := c.Get("key"); err != nil {
+		t.Fatalf("Get() = _, %v, want no error", err)
+	}
+
+	c.DeleteExpired()
+	if _, err := c.Get("key"); err != nil {
+		t.Fatalf("Get() after DeleteExpired() = _, %v, want no error", err)
+	}
+
+	keys := c.GetAllValidKey()
+	if len(keys) != 1 || keys[0] != "key" {
+		t.Fatalf("GetAllValidKey() = %v, want [key]", keys)
+	}
+}
+
+func TestNoExpirationRenewOnGet(t *testing.T) {
+	ctx := context.Background()
+	c := New(ctx, Options{Expiration: NoExpiration, RenewExpirationOnGet: true})
+
+	c.Set("key", "value")
+	if _, err := c.Get("key"); err != nil {
+		t.Fatalf("Get() = _, %v, want no error", err)
+	}
+
+	exp, _ := c.Expired("key")
+	if !exp.IsZero() {
+		t.Fatalf("Expired() time = %v, want zero time after renew", exp)
+	}
+}
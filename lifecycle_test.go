@@ -0,0 +1,37 @@
+package cache
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// newAbandonedCache returns without holding a reference to the *Cache it
+// creates, so nothing keeps it reachable once this call returns (the cleanup
+// goroutine holds only the inner cache, not the *Cache).
+func newAbandonedCache() {
+	ctx := context.Background() // deliberately never cancelled
+	c := New(ctx, Options{Expiration: time.Minute, CleanupInterval: time.Millisecond})
+	c.Set("a", 1)
+}
+
+func TestCleanupGoroutineStopsWhenCacheIsUnreachable(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	newAbandonedCache()
+
+	// The background cleanup goroutine only exits once the finalizer set on
+	// the Cache's TypedCache wrapper runs, which requires at least one GC
+	// after the wrapper becomes unreachable.
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+		if runtime.NumGoroutine() <= before {
+			return
+		}
+	}
+
+	t.Fatalf("goroutine count = %d, want <= %d after GC (cleanup goroutine leaked)", runtime.NumGoroutine(), before)
+}
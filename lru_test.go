@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// singleShardHash forces every key into shard 0 so capacity/eviction order
+// is deterministic regardless of the default hash's distribution.
+func singleShardHash(string) byte { return 0 }
+
+func TestCacheLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	var evicted []string
+	c := New(ctx, Options{
+		Expiration: time.Minute,
+		Hash:       singleShardHash,
+		MaxItems:   128, // 1 item per shard, all keys land in shard 0
+		OnCapacityEvict: func(key string, _ interface{}) {
+			evicted = append(evicted, key)
+		},
+	})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	if c.Len() != 1 {
+		t.Fatalf("Len() = %d, want 1", c.Len())
+	}
+	if len(evicted) != 1 || evicted[0] != "a" {
+		t.Fatalf("evicted = %v, want [a]", evicted)
+	}
+	if _, err := c.Get("b"); err != nil {
+		t.Fatalf("Get(b) = %v, want nil", err)
+	}
+}
+
+func TestCacheLRUGetRefreshesRecency(t *testing.T) {
+	ctx := context.Background()
+	var evicted []string
+	c := New(ctx, Options{
+		Expiration: time.Minute,
+		Hash:       singleShardHash,
+		MaxItems:   256, // 2 items per shard
+		OnCapacityEvict: func(key string, _ interface{}) {
+			evicted = append(evicted, key)
+		},
+	})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	// Touch "a" so "b" becomes the least-recently-used entry.
+	if _, err := c.Get("a"); err != nil {
+		t.Fatalf("Get(a) = %v, want nil", err)
+	}
+
+	c.Set("c", 3)
+
+	if len(evicted) != 1 || evicted[0] != "b" {
+		t.Fatalf("evicted = %v, want [b]", evicted)
+	}
+	if _, err := c.Get("a"); err != nil {
+		t.Fatalf("Get(a) after eviction = %v, want nil", err)
+	}
+	if _, err := c.Get("c"); err != nil {
+		t.Fatalf("Get(c) = %v, want nil", err)
+	}
+}
@@ -0,0 +1,123 @@
+package cache
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// persistVersion identifies the on-disk layout written by Save so Load can
+// reject snapshots from an incompatible future version.
+const persistVersion = 1
+
+type persistHeader struct {
+	Version           int
+	ShardCount        int
+	DefaultExpiration time.Duration
+}
+
+type persistRecord struct {
+	Key        string
+	Object     interface{}
+	Expiration time.Time
+}
+
+// Register pre-registers a concrete type with encoding/gob so values stored
+// behind the cache's interface{} Object field can be encoded and decoded by
+// Save/Load. Call it once per concrete type before using Save or Load.
+func Register(value interface{}) {
+	gob.Register(value)
+}
+
+// Save gob-encodes the cache contents to w, so they can be restored with
+// Load across process restarts.
+func (c *Cache) Save(w io.Writer) error {
+	c.mu.RLock()
+	records := make([]persistRecord, 0, 128)
+	for i := 0; i < 128; i++ {
+		c.rwMu[i].RLock()
+		for k, v := range c.items[i] {
+			records = append(records, persistRecord{Key: k, Object: v.Object, Expiration: v.Expiration})
+		}
+		c.rwMu[i].RUnlock()
+	}
+	header := persistHeader{
+		Version:           persistVersion,
+		ShardCount:        128,
+		DefaultExpiration: c.expiration,
+	}
+	c.mu.RUnlock()
+
+	enc := gob.NewEncoder(w)
+	if err := enc.Encode(header); err != nil {
+		return err
+	}
+	return enc.Encode(records)
+}
+
+// SaveFile gob-encodes the cache contents to the file at path, creating or
+// truncating it as needed.
+func (c *Cache) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return c.Save(f)
+}
+
+// Load restores cache contents previously written by Save. Already-expired
+// items are skipped, and onEvicted fires for any keys being overwritten.
+func (c *Cache) Load(r io.Reader) error {
+	dec := gob.NewDecoder(r)
+
+	var header persistHeader
+	if err := dec.Decode(&header); err != nil {
+		return err
+	}
+	if header.Version != persistVersion {
+		return fmt.Errorf("cache: unsupported snapshot version %d (want %d)", header.Version, persistVersion)
+	}
+	var records []persistRecord
+	if err := dec.Decode(&records); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, rec := range records {
+		if isExpired(rec.Expiration) {
+			continue
+		}
+		i := c.hash(rec.Key)
+		c.rwMu[i].Lock()
+		if old, found := c.items[i][rec.Key]; found {
+			if c.onEvicted != nil {
+				c.onEvicted(rec.Key, old.Object)
+			}
+			old.Object = rec.Object
+			old.Expiration = rec.Expiration
+			c.touchLRU(i, old, rec.Key)
+		} else {
+			item := &Item[interface{}]{Object: rec.Object, Expiration: rec.Expiration}
+			c.items[i][rec.Key] = item
+			c.touchLRU(i, item, rec.Key)
+			c.evictLRUIfFull(i)
+		}
+		c.rwMu[i].Unlock()
+	}
+	return nil
+}
+
+// LoadFile restores cache contents previously written by SaveFile.
+func (c *Cache) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return c.Load(f)
+}
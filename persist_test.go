@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"testing"
+	"time"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	c := New(ctx, Options{Expiration: time.Minute})
+	c.Set("a", "hello")
+	c.Set("b", 42)
+
+	var buf bytes.Buffer
+	if err := c.Save(&buf); err != nil {
+		t.Fatalf("Save() = %v, want nil", err)
+	}
+
+	c2 := New(ctx, Options{Expiration: time.Minute})
+	if err := c2.Load(&buf); err != nil {
+		t.Fatalf("Load() = %v, want nil", err)
+	}
+
+	if v, err := c2.Get("a"); err != nil || v != "hello" {
+		t.Fatalf("Get(a) = %v, %v, want \"hello\", nil", v, err)
+	}
+	if v, err := c2.Get("b"); err != nil || v != 42 {
+		t.Fatalf("Get(b) = %v, %v, want 42, nil", v, err)
+	}
+}
+
+func TestSaveLoadSkipsExpiredItems(t *testing.T) {
+	ctx := context.Background()
+	c := New(ctx, Options{Expiration: time.Millisecond})
+	c.Set("a", "gone")
+	time.Sleep(10 * time.Millisecond)
+
+	var buf bytes.Buffer
+	if err := c.Save(&buf); err != nil {
+		t.Fatalf("Save() = %v, want nil", err)
+	}
+
+	c2 := New(ctx, Options{Expiration: time.Minute})
+	if err := c2.Load(&buf); err != nil {
+		t.Fatalf("Load() = %v, want nil", err)
+	}
+
+	if _, err := c2.Get("a"); err != ErrNotFound {
+		t.Fatalf("Get(a) = %v, want ErrNotFound", err)
+	}
+}
+
+func TestLoadRejectsIncompatibleVersion(t *testing.T) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	if err := enc.Encode(persistHeader{Version: persistVersion + 1, ShardCount: 128}); err != nil {
+		t.Fatalf("Encode(header) = %v, want nil", err)
+	}
+	if err := enc.Encode([]persistRecord{}); err != nil {
+		t.Fatalf("Encode(records) = %v, want nil", err)
+	}
+
+	ctx := context.Background()
+	c := New(ctx, Options{Expiration: time.Minute})
+	if err := c.Load(&buf); err == nil {
+		t.Fatal("Load() with a future version = nil, want an error")
+	}
+}
@@ -0,0 +1,624 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Item holds a cached value along with its expiration time. elem points at
+// this item's node in its shard's LRU list when MaxItems is set; it is nil
+// otherwise.
+type Item[V any] struct {
+	Object     V
+	Expiration time.Time
+	elem       *list.Element
+}
+
+// ItemMap is the storage for a single shard.
+type ItemMap[K comparable, V any] map[K]*Item[V]
+
+// TypedHashFunc maps a key to a shard index in the range [0, 128).
+type TypedHashFunc[K comparable] func(key K) byte
+
+// TypedCache is the generic, type-safe counterpart of Cache. It keeps the
+// same 128-shard rwMu/items design but stores V directly instead of
+// interface{}, so callers don't need type assertions at every call site.
+type TypedCache[K comparable, V any] struct {
+	*typedCache[K, V]
+}
+
+// TypedOptions configures a TypedCache. See Cache's Options for field semantics.
+type TypedOptions[K comparable, V any] struct {
+	// Expiration time: used to calculate the item expire time
+	// object expire time: time.Now() + Options.Expiration
+	Expiration time.Duration
+	// Background cleanup interval
+	CleanupInterval time.Duration
+	// Optional hash function for cache partition
+	// The return should be range [0, 128)
+	// Default hash function using last byte as the partition key
+	Hash TypedHashFunc[K]
+	// Optional function that is called when an object is removed from the cache
+	Evicted func(K, V)
+	// Whether extend the expiration time on a Get operation
+	RenewExpirationOnGet bool
+	// Item limit, enforced per-shard via LRU eviction: each of the 128 shards
+	// holds at most MaxItems/128 items (at least 1), so the total can exceed
+	// MaxItems when it is below 128. 0 (the default) means unbounded.
+	MaxItems int
+	// Optional function called when an item is evicted to make room under
+	// MaxItems. Evicted is not called for these evictions; it fires on
+	// expiration, overwrite and Delete.
+	OnCapacityEvict func(K, V)
+}
+
+type typedCache[K comparable, V any] struct {
+	mu               sync.RWMutex
+	expiration       time.Duration
+	rwMu             [128]sync.RWMutex
+	items            [128]ItemMap[K, V]
+	lists            [128]*list.List // per-shard LRU order; nil unless maxItemsPerShard > 0
+	maxItemsPerShard int
+	onEvicted        func(K, V)
+	onCapacityEvict  func(K, V)
+	hash             TypedHashFunc[K]
+	renewOnGet       bool
+	// stop lets the finalizer set on the TypedCache wrapper tell
+	// typedBackgroundCleanup to exit once the wrapper becomes unreachable,
+	// even if the caller never cancels ctx. Nil when CleanupInterval == 0.
+	stop chan struct{}
+}
+
+// effectiveExpiration computes the absolute expiration for an item given a
+// configured duration. NoExpiration maps to the zero time, which is treated
+// throughout the package as "never expires".
+func effectiveExpiration(d time.Duration) time.Time {
+	if d == NoExpiration {
+		return time.Time{}
+	}
+	return time.Now().Add(d)
+}
+
+// isExpired reports whether exp is a real (non-zero) expiration in the past.
+func isExpired(exp time.Time) bool {
+	return !exp.IsZero() && time.Now().After(exp)
+}
+
+// isValid is the complement of isExpired.
+func isValid(exp time.Time) bool {
+	return exp.IsZero() || time.Now().Before(exp)
+}
+
+// shouldRenew reports whether next should replace current as an item's
+// expiration. A zero current means the item never expires and must not be
+// shortened; a zero next always extends, since it means "never expires".
+func shouldRenew(current, next time.Time) bool {
+	if current.IsZero() {
+		return false
+	}
+	if next.IsZero() {
+		return true
+	}
+	return next.After(current)
+}
+
+// touchLRU records key as the most-recently-used entry for shard i, pushing
+// it to the front if it isn't tracked yet. It is a no-op when MaxItems isn't
+// configured. Caller must hold c.rwMu[i] for writing.
+func (c *typedCache[K, V]) touchLRU(i byte, item *Item[V], key K) {
+	if c.lists[i] == nil {
+		return
+	}
+	if item.elem == nil {
+		item.elem = c.lists[i].PushFront(key)
+		return
+	}
+	c.lists[i].MoveToFront(item.elem)
+}
+
+// evictLRUIfFull drops the least-recently-used entry in shard i once it
+// exceeds maxItemsPerShard. Caller must hold c.rwMu[i] for writing.
+func (c *typedCache[K, V]) evictLRUIfFull(i byte) {
+	if c.lists[i] == nil || c.lists[i].Len() <= c.maxItemsPerShard {
+		return
+	}
+	back := c.lists[i].Back()
+	if back == nil {
+		return
+	}
+	key := back.Value.(K)
+	c.lists[i].Remove(back)
+	if v, found := c.items[i][key]; found {
+		delete(c.items[i], key)
+		if c.onCapacityEvict != nil {
+			c.onCapacityEvict(key, v.Object)
+		}
+	}
+}
+
+// defaultHashFunc uses the last byte of the key as the partition key. Keys
+// are formatted with fmt.Sprintf when K isn't string, so any comparable key
+// (including constraints.Ordered-like types) gets a usable default.
+func defaultHashFunc[K comparable](key K) byte {
+	s, ok := any(key).(string)
+	if !ok {
+		s = fmt.Sprintf("%v", key)
+	}
+	if len(s) == 0 {
+		return 0
+	}
+	return s[len(s)-1] & 0x7F
+}
+
+// 1.If the key not found in cache, return an emtpy time and true
+// 2.If the key still in cache but the object is expired, return expration time and true
+// 3.The object still valid, return object's expration time and false
+func (c *TypedCache[K, V]) Expired(key K) (time.Time, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	i := c.hash(key)
+
+	c.rwMu[i].RLock()
+	defer c.rwMu[i].RUnlock()
+
+	v, found := c.items[i][key]
+	if !found {
+		return time.Time{}, true
+	}
+	return v.Expiration, isExpired(v.Expiration)
+}
+
+// Add a new object to cache
+// If the key exist, will update the value, old value will be free
+func (c *TypedCache[K, V]) Set(key K, object V) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i := c.hash(key)
+	c.rwMu[i].Lock()
+	defer c.rwMu[i].Unlock()
+
+	if v, found := c.items[i][key]; found {
+		if c.onEvicted != nil {
+			c.onEvicted(key, v.Object)
+		}
+		v.Object = object
+		v.Expiration = effectiveExpiration(c.expiration)
+		c.touchLRU(i, v, key)
+		return
+	}
+
+	item := &Item[V]{
+		Object:     object,
+		Expiration: effectiveExpiration(c.expiration),
+	}
+	c.items[i][key] = item
+	c.touchLRU(i, item, key)
+	c.evictLRUIfFull(i)
+}
+
+// Put a object into cache with a specific expiration time
+func (c *TypedCache[K, V]) SetWithExpiration(key K, object V, expiration time.Duration) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i := c.hash(key)
+	c.rwMu[i].Lock()
+	defer c.rwMu[i].Unlock()
+
+	if v, found := c.items[i][key]; found {
+		if c.onEvicted != nil {
+			c.onEvicted(key, v.Object)
+		}
+		v.Object = object
+		v.Expiration = effectiveExpiration(expiration)
+		c.touchLRU(i, v, key)
+		return nil
+	}
+
+	item := &Item[V]{
+		Object:     object,
+		Expiration: effectiveExpiration(expiration),
+	}
+	c.items[i][key] = item
+	c.touchLRU(i, item, key)
+	c.evictLRUIfFull(i)
+	return nil
+}
+
+// Repalce an object
+// If not found, will return err ErrNotFound, will not add the obejct into cache
+func (c *TypedCache[K, V]) Replace(key K, object V) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i := c.hash(key)
+	c.rwMu[i].Lock()
+	defer c.rwMu[i].Unlock()
+	v, found := c.items[i][key]
+	if !found {
+		return ErrNotFound
+	}
+	if c.onEvicted != nil {
+		c.onEvicted(key, v.Object)
+	}
+	v.Object = object
+	v.Expiration = effectiveExpiration(c.expiration)
+	return nil
+}
+
+// Get an object from the cache
+// If option RenewExpirationOnGet is enable, may update the object expiration time
+func (c *TypedCache[K, V]) Get(key K) (V, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var zero V
+	i := c.hash(key)
+	c.rwMu[i].RLock()
+	if v, found := c.items[i][key]; found {
+		if isExpired(v.Expiration) {
+			c.rwMu[i].RUnlock()
+			return zero, ErrExpired
+		}
+		if !c.renewOnGet && c.lists[i] == nil {
+			c.rwMu[i].RUnlock()
+			return v.Object, nil
+		}
+		c.rwMu[i].RUnlock()
+
+		c.rwMu[i].Lock()
+		c.touchLRU(i, v, key)
+		newExpiration := effectiveExpiration(c.expiration)
+		// Only update expiration time when new expiration extends the current one
+		if c.renewOnGet && shouldRenew(v.Expiration, newExpiration) {
+			v.Expiration = newExpiration
+		}
+		c.rwMu[i].Unlock()
+		return v.Object, nil
+	}
+	c.rwMu[i].RUnlock()
+	return zero, ErrNotFound
+}
+
+// Extend the expiration time with a specific time
+// it only works when RenewExpirationOnGet is enabled
+func (c *TypedCache[K, V]) GetWithExpiration(key K) (V, time.Time, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var zero V
+	i := c.hash(key)
+	c.rwMu[i].RLock()
+	if v, found := c.items[i][key]; found {
+		if !c.renewOnGet && c.lists[i] == nil {
+			c.rwMu[i].RUnlock()
+			return v.Object, v.Expiration, nil
+		}
+		c.rwMu[i].RUnlock()
+
+		c.rwMu[i].Lock()
+		c.touchLRU(i, v, key)
+		newExpiration := effectiveExpiration(c.expiration)
+		// Only update expiration time when new expiration extends the current one
+		if c.renewOnGet && shouldRenew(v.Expiration, newExpiration) {
+			v.Expiration = newExpiration
+		}
+		c.rwMu[i].Unlock()
+		return v.Object, v.Expiration, nil
+	}
+	c.rwMu[i].RUnlock()
+	return zero, time.Time{}, ErrNotFound
+}
+
+// Manually delete objects from the cache, Does nothing if the object does not exist
+func (c *TypedCache[K, V]) Delete(keys []K) {
+	if len(keys) == 0 {
+		return
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, key := range keys {
+		i := c.hash(key)
+		c.rwMu[i].Lock()
+		if v, found := c.items[i][key]; found {
+			delete(c.items[i], key)
+			if c.lists[i] != nil && v.elem != nil {
+				c.lists[i].Remove(v.elem)
+			}
+			if c.onEvicted != nil {
+				c.onEvicted(key, v.Object)
+			}
+		}
+		c.rwMu[i].Unlock()
+	}
+}
+
+// Len returns the total number of items currently stored across all shards,
+// including expired items not yet reaped by DeleteExpired.
+func (c *TypedCache[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	n := 0
+	for i := 0; i < 128; i++ {
+		c.rwMu[i].RLock()
+		n += len(c.items[i])
+		c.rwMu[i].RUnlock()
+	}
+	return n
+}
+
+// Enable the option RenewExpirationOnGet]
+// Get() will extend the object exipration after EnableRenewOnGet()
+func (c *TypedCache[K, V]) EnableRenewOnGet() {
+	c.mu.Lock()
+	c.renewOnGet = true
+	c.mu.Unlock()
+}
+
+// Disable the option RenewExpirationOnGet
+// Get() dosen't extend the object exipration time after DisableRenewOnGet()
+func (c *TypedCache[K, V]) DisableRenewOnGet() {
+	c.mu.Lock()
+	c.renewOnGet = false
+	c.mu.Unlock()
+}
+
+// Set the cache expration time which used to calculate object expiration time
+// Existing objects keep their expiration time until it is next computed:
+// Set, Replace and Touch use the new value, and if RenewExpirationOnGet is enabled,
+// Get an object will re-calculate the expiration time with the new Expiration
+func (c *TypedCache[K, V]) SetExpirationTime(expiration time.Duration) {
+	c.mu.Lock()
+	c.expiration = expiration
+	c.mu.Unlock()
+}
+
+func (c *TypedCache[K, V]) GetExpirationTime() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.expiration
+}
+
+// Renew the expiration time for the given key
+// 1. If the key is not found, return ErrNotFound
+// 2. If the key still exist, will renew the expiration time
+func (c *TypedCache[K, V]) Touch(key K) (V, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var zero V
+	i := c.hash(key)
+	c.rwMu[i].Lock()
+	defer c.rwMu[i].Unlock()
+
+	v, found := c.items[i][key]
+	if !found {
+		return zero, ErrNotFound
+	}
+
+	newExpiration := effectiveExpiration(c.expiration)
+	if shouldRenew(v.Expiration, newExpiration) {
+		v.Expiration = newExpiration
+	}
+	return v.Object, nil
+}
+
+// Return all item key in the cache, including the expired items
+// GetAll don't update expiration time even RenewExpirationOnGet is enabled
+func (c *TypedCache[K, V]) GetAllKey() []K {
+	keys := make([]K, 0, 128)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for i := 0; i < 128; i++ {
+		c.rwMu[i].RLock()
+		for k := range c.items[i] {
+			keys = append(keys, k)
+		}
+		c.rwMu[i].RUnlock()
+	}
+	return keys
+}
+
+// Get all valid object in the cache, return the Keys
+// Don't update expiration time even RenewExpirationOnGet is enabled
+func (c *TypedCache[K, V]) GetAllValidKey() []K {
+	keys := make([]K, 0, 128)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for i := 0; i < 128; i++ {
+		c.rwMu[i].RLock()
+		for k, v := range c.items[i] {
+			if isValid(v.Expiration) {
+				keys = append(keys, k)
+			}
+		}
+		c.rwMu[i].RUnlock()
+	}
+	return keys
+}
+
+// Return all objects in the cache keyed by their keys, including the expired items
+// Don't update expiration time even RenewExpirationOnGet is enabled
+func (c *TypedCache[K, V]) GetAllObject() map[K]V {
+	items := make(map[K]V)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for i := 0; i < 128; i++ {
+		c.rwMu[i].RLock()
+		for k, v := range c.items[i] {
+			items[k] = v.Object
+		}
+		c.rwMu[i].RUnlock()
+	}
+	return items
+}
+
+// Get all valid object in the cache, return the object
+// Don't update expiration time even RenewExpirationOnGet is enabled
+func (c *TypedCache[K, V]) GetAllValidObject() map[K]V {
+	items := make(map[K]V)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for i := 0; i < 128; i++ {
+		c.rwMu[i].RLock()
+		for k, v := range c.items[i] {
+			if isValid(v.Expiration) {
+				items[k] = v.Object
+			}
+		}
+		c.rwMu[i].RUnlock()
+	}
+	return items
+}
+
+// typedBackgroundCleanup only holds the inner *typedCache, never the
+// *TypedCache wrapper returned to callers, so an abandoned wrapper is still
+// eligible for GC: stop (closed by the wrapper's finalizer) is what lets
+// this goroutine exit when the caller never cancels ctx.
+func typedBackgroundCleanup[K comparable, V any](ctx context.Context, c *typedCache[K, V], interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	for {
+		select {
+		case <-ticker.C:
+			(&TypedCache[K, V]{c}).DeleteExpired()
+		case <-c.stop:
+			ticker.Stop()
+			return
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+// stopTypedCleanup is attached as a finalizer on the TypedCache wrapper so
+// typedBackgroundCleanup stops once the wrapper is unreachable, even if the
+// caller dropped it without cancelling ctx.
+func stopTypedCleanup[K comparable, V any](w *TypedCache[K, V]) {
+	close(w.stop)
+}
+
+// Delete all expired objects from the cache.
+func (c *TypedCache[K, V]) DeleteExpired() {
+	evictedItems := make([]K, 0, 128)
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for i := 0; i < 128; i++ {
+		c.rwMu[i].RLock()
+		for k, v := range c.items[i] {
+			if isExpired(v.Expiration) {
+				evictedItems = append(evictedItems, k)
+			}
+		}
+		c.rwMu[i].RUnlock()
+	}
+
+	for _, k := range evictedItems {
+		i := c.hash(k)
+		c.rwMu[i].Lock()
+		v, found := c.items[i][k]
+		// Double check
+		if found && isExpired(v.Expiration) {
+			delete(c.items[i], k)
+			if c.lists[i] != nil && v.elem != nil {
+				c.lists[i].Remove(v.elem)
+			}
+			if c.onEvicted != nil {
+				c.onEvicted(k, v.Object)
+			}
+		}
+		c.rwMu[i].Unlock()
+	}
+}
+
+// NewTyped creates a TypedCache[K, V].
+func NewTyped[K comparable, V any](ctx context.Context, opt TypedOptions[K, V]) *TypedCache[K, V] {
+	c := &typedCache[K, V]{
+		hash:       defaultHashFunc[K],
+		expiration: DefaultExpiration,
+		onEvicted:  opt.Evicted,
+		renewOnGet: opt.RenewExpirationOnGet,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := 0; i < 128; i++ {
+		c.items[i] = make(ItemMap[K, V])
+	}
+
+	if opt.Expiration != 0 {
+		c.expiration = opt.Expiration
+	}
+
+	if opt.Hash != nil {
+		c.hash = opt.Hash
+	}
+
+	if opt.MaxItems > 0 {
+		shardCap := opt.MaxItems / 128
+		if shardCap == 0 {
+			shardCap = 1
+		}
+		c.maxItemsPerShard = shardCap
+		c.onCapacityEvict = opt.OnCapacityEvict
+		for i := 0; i < 128; i++ {
+			c.lists[i] = list.New()
+		}
+	}
+
+	wrapper := &TypedCache[K, V]{c}
+	if opt.CleanupInterval > 0 {
+		c.stop = make(chan struct{})
+		go typedBackgroundCleanup(ctx, c, opt.CleanupInterval)
+		runtime.SetFinalizer(wrapper, stopTypedCleanup[K, V])
+	}
+	return wrapper
+}
+
+// Clear the cache, all objects in cache will be deleted
+func (c *TypedCache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := 0; i < 128; i++ {
+		c.items[i] = make(ItemMap[K, V])
+		if c.lists[i] != nil {
+			c.lists[i] = list.New()
+		}
+	}
+}
+
+// Clear the cache, compare to function Clear(), Flush() will call onEvicted for the object in cache
+func (c *TypedCache[K, V]) Flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := 0; i < 128; i++ {
+		if c.onEvicted != nil {
+			for k, v := range c.items[i] {
+				c.onEvicted(k, v.Object)
+			}
+		}
+		c.items[i] = make(ItemMap[K, V])
+		if c.lists[i] != nil {
+			c.lists[i] = list.New()
+		}
+	}
+}
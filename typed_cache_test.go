@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTypedCacheIntKeys(t *testing.T) {
+	ctx := context.Background()
+	c := NewTyped[int, string](ctx, TypedOptions[int, string]{Expiration: time.Minute})
+
+	c.Set(1, "one")
+	c.Set(2, "two")
+
+	v, err := c.Get(1)
+	if err != nil || v != "one" {
+		t.Fatalf("Get(1) = %q, %v, want \"one\", nil", v, err)
+	}
+
+	if err := c.Replace(2, "deux"); err != nil {
+		t.Fatalf("Replace(2) = %v, want nil", err)
+	}
+	if v, _ := c.Get(2); v != "deux" {
+		t.Fatalf("Get(2) = %q, want \"deux\"", v)
+	}
+
+	c.Delete([]int{1})
+	if _, err := c.Get(1); err != ErrNotFound {
+		t.Fatalf("Get(1) after Delete = %v, want ErrNotFound", err)
+	}
+
+	keys := c.GetAllKey()
+	if len(keys) != 1 || keys[0] != 2 {
+		t.Fatalf("GetAllKey() = %v, want [2]", keys)
+	}
+}
+
+func TestCacheDeleteFiltersEmptyKey(t *testing.T) {
+	ctx := context.Background()
+	c := New(ctx, Options{
+		Expiration: time.Minute,
+		Hash: func(s string) byte {
+			return s[len(s)-1] & 0x7F
+		},
+	})
+
+	c.Set("a", 1)
+
+	// With a custom Hash that indexes s[len(s)-1], Delete([]string{""}) must
+	// not panic: the empty key has to be filtered out before hashing.
+	c.Delete([]string{"", "a"})
+
+	if _, err := c.Get("a"); err != ErrNotFound {
+		t.Fatalf("Get(a) after Delete = %v, want ErrNotFound", err)
+	}
+}